@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dukerupert/annuminas/pkg/dockerhub/reconcile"
+	"github.com/spf13/cobra"
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Reconcile repositories, tokens, and webhooks from a YAML manifest",
+	Long: `Apply parses a YAML manifest describing the desired state of a
+namespace's repositories, their webhooks, and access tokens, then reconciles
+Docker Hub to match it: creating missing objects, patching drifted fields,
+and — with --prune — deleting objects absent from the manifest.
+
+Use --dry-run to print the plan without making any changes.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		if namespace == "" {
+			namespace = client.Username
+		}
+		file, _ := cmd.Flags().GetString("file")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		prune, _ := cmd.Flags().GetBool("prune")
+		tokensOut, _ := cmd.Flags().GetString("tokens-out")
+
+		manifest, err := reconcile.ParseManifest(file)
+		if err != nil {
+			return err
+		}
+
+		secretWriter := os.Stdout
+		if tokensOut != "" {
+			f, err := os.OpenFile(tokensOut, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+			if err != nil {
+				return fmt.Errorf("open tokens-out: %w", err)
+			}
+			defer f.Close()
+			secretWriter = f
+		}
+
+		cfg := reconcile.Config{
+			DryRun:       dryRun,
+			Prune:        prune,
+			SecretWriter: secretWriter,
+		}
+
+		diff, err := reconcile.Apply(cmd.Context(), client, namespace, manifest, cfg)
+		if err != nil {
+			return err
+		}
+
+		if dryRun {
+			fmt.Println("Dry run — no changes made.")
+		}
+		diff.Print(os.Stdout)
+		return nil
+	},
+}
+
+func init() {
+	applyCmd.Flags().String("namespace", "", "Namespace (user or org); defaults to DOCKERHUB_USERNAME")
+	applyCmd.Flags().StringP("file", "f", "", "Path to the YAML manifest (required)")
+	applyCmd.MarkFlagRequired("file")
+	applyCmd.Flags().Bool("dry-run", false, "Print the reconciliation plan without making changes")
+	applyCmd.Flags().Bool("prune", false, "Delete objects present in the namespace but absent from the manifest")
+	applyCmd.Flags().String("tokens-out", "", "Path to write newly created access token secrets (default: stdout)")
+
+	rootCmd.AddCommand(applyCmd)
+}
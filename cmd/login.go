@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dukerupert/annuminas/pkg/dockerhub/credentials"
+	"github.com/spf13/cobra"
+)
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Store Docker Hub credentials in the selected credential store",
+	Long: `Login writes a Docker Hub username and access token to the
+credential store selected by --credential-store (env, docker, or keychain),
+so subsequent commands don't need DOCKERHUB_USERNAME/DOCKERHUB_TOKEN set.
+
+The secret is always read from stdin rather than a flag, so it never appears
+in shell history or a process listing:
+
+  echo "$DOCKERHUB_TOKEN" | annuminas login --username alice`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		storeName, _ := cmd.Flags().GetString("credential-store")
+		username, _ := cmd.Flags().GetString("username")
+		if username == "" {
+			return fmt.Errorf("--username is required")
+		}
+
+		secret, err := readPassword(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+
+		store, err := credentials.NewStore(storeName)
+		if err != nil {
+			return err
+		}
+
+		if err := store.Set(credentials.DockerHubRegistry, username, secret); err != nil {
+			return fmt.Errorf("store credentials: %w", err)
+		}
+
+		fmt.Printf("Login succeeded via %s credential store.\n", storeName)
+		return nil
+	},
+}
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove stored Docker Hub credentials",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		storeName, _ := cmd.Flags().GetString("credential-store")
+
+		store, err := credentials.NewStore(storeName)
+		if err != nil {
+			return err
+		}
+
+		if err := store.Delete(credentials.DockerHubRegistry); err != nil {
+			return fmt.Errorf("remove credentials: %w", err)
+		}
+
+		fmt.Printf("Logged out of %s credential store.\n", storeName)
+		return nil
+	},
+}
+
+// readPassword reads a single line from r, trimming the trailing newline.
+func readPassword(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("read password from stdin: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("no password read from stdin")
+	}
+	return password, nil
+}
+
+func init() {
+	loginCmd.Flags().String("username", "", "Docker Hub username (required)")
+	loginCmd.MarkFlagRequired("username")
+
+	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(logoutCmd)
+}
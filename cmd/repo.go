@@ -5,6 +5,7 @@ import (
 	"os"
 	"text/tabwriter"
 
+	"github.com/dukerupert/annuminas/pkg/dockerhub"
 	"github.com/spf13/cobra"
 )
 
@@ -22,7 +23,7 @@ var repoListCmd = &cobra.Command{
 			namespace = client.Username
 		}
 
-		repos, err := client.ListRepos(namespace)
+		repos, err := client.ListRepos(cmd.Context(), namespace)
 		if err != nil {
 			return err
 		}
@@ -58,7 +59,7 @@ var repoGetCmd = &cobra.Command{
 			namespace = client.Username
 		}
 
-		repo, err := client.GetRepo(namespace, args[0])
+		repo, err := client.GetRepo(cmd.Context(), namespace, args[0])
 		if err != nil {
 			return err
 		}
@@ -87,7 +88,7 @@ var repoCreateCmd = &cobra.Command{
 		private, _ := cmd.Flags().GetBool("private")
 		description, _ := cmd.Flags().GetString("description")
 
-		repo, err := client.CreateRepo(namespace, name, description, private)
+		repo, err := client.CreateRepo(cmd.Context(), namespace, name, description, private)
 		if err != nil {
 			return err
 		}
@@ -107,7 +108,7 @@ var repoDeleteCmd = &cobra.Command{
 		}
 		name, _ := cmd.Flags().GetString("name")
 
-		if err := client.DeleteRepo(namespace, name); err != nil {
+		if err := client.DeleteRepo(cmd.Context(), namespace, name); err != nil {
 			return err
 		}
 
@@ -116,6 +117,62 @@ var repoDeleteCmd = &cobra.Command{
 	},
 }
 
+var repoDescribeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Update a repository's short and full descriptions",
+	Long: `Update a repository's short description and/or its full (markdown)
+description — the text rendered on the repo's Docker Hub page.
+
+Use --readme to sync the full description from a local file, e.g. a
+project's README.md, instead of passing --full-description inline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		if namespace == "" {
+			namespace = client.Username
+		}
+		name, _ := cmd.Flags().GetString("name")
+		description, _ := cmd.Flags().GetString("description")
+		fullDescription, _ := cmd.Flags().GetString("full-description")
+		readme, _ := cmd.Flags().GetString("readme")
+		readmeMaxBytes, _ := cmd.Flags().GetInt64("readme-max-bytes")
+
+		if readme != "" && cmd.Flags().Changed("full-description") {
+			return fmt.Errorf("--readme and --full-description are mutually exclusive")
+		}
+
+		var patch dockerhub.RepoPatch
+		if cmd.Flags().Changed("description") {
+			patch.Description = &description
+		}
+
+		if readme != "" {
+			data, err := os.ReadFile(readme)
+			if err != nil {
+				return fmt.Errorf("read readme file: %w", err)
+			}
+			if int64(len(data)) > readmeMaxBytes {
+				return fmt.Errorf("%s is %d bytes, exceeds --readme-max-bytes (%d)", readme, len(data), readmeMaxBytes)
+			}
+			full := string(data)
+			patch.FullDescription = &full
+		} else if cmd.Flags().Changed("full-description") {
+			patch.FullDescription = &fullDescription
+		}
+
+		if patch.Description == nil && patch.FullDescription == nil {
+			return fmt.Errorf("at least one of --description, --full-description, or --readme is required")
+		}
+
+		repo, err := client.UpdateRepo(cmd.Context(), namespace, name, patch)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Repository updated: %s/%s\n", repo.Namespace, repo.Name)
+		return nil
+	},
+}
+
 var repoEnsureCmd = &cobra.Command{
 	Use:   "ensure",
 	Short: "Create a repository if it doesn't exist (idempotent)",
@@ -126,7 +183,7 @@ var repoEnsureCmd = &cobra.Command{
 		}
 		name, _ := cmd.Flags().GetString("name")
 
-		if err := client.EnsureRepo(namespace, name); err != nil {
+		if err := client.EnsureRepo(cmd.Context(), namespace, name); err != nil {
 			return err
 		}
 
@@ -149,6 +206,14 @@ func init() {
 	repoDeleteCmd.Flags().String("name", "", "Repository name (required)")
 	repoDeleteCmd.MarkFlagRequired("name")
 
+	// repo describe flags
+	repoDescribeCmd.Flags().String("name", "", "Repository name (required)")
+	repoDescribeCmd.MarkFlagRequired("name")
+	repoDescribeCmd.Flags().String("description", "", "Short description for the repository")
+	repoDescribeCmd.Flags().String("full-description", "", "Long markdown description rendered on the repo page")
+	repoDescribeCmd.Flags().String("readme", "", "Path to a file (e.g. README.md) to use as the full description")
+	repoDescribeCmd.Flags().Int64("readme-max-bytes", 25000, "Maximum allowed size of --readme content; Docker Hub caps full_description around 25KB")
+
 	// repo ensure flags
 	repoEnsureCmd.Flags().String("name", "", "Repository name (required)")
 	repoEnsureCmd.MarkFlagRequired("name")
@@ -157,6 +222,7 @@ func init() {
 	repoCmd.AddCommand(repoGetCmd)
 	repoCmd.AddCommand(repoCreateCmd)
 	repoCmd.AddCommand(repoDeleteCmd)
+	repoCmd.AddCommand(repoDescribeCmd)
 	repoCmd.AddCommand(repoEnsureCmd)
 	rootCmd.AddCommand(repoCmd)
 }
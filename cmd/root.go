@@ -1,52 +1,80 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"path/filepath"
+	"os/signal"
+	"time"
 
 	"github.com/dukerupert/annuminas/pkg/dockerhub"
-	"github.com/joho/godotenv"
+	"github.com/dukerupert/annuminas/pkg/dockerhub/credentials"
 	"github.com/spf13/cobra"
 )
 
 var client *dockerhub.Client
 
+var cancelTimeout context.CancelFunc
+
+// credentialFreeCommands don't need a Client — they set up or tear down the
+// credentials the rest of the CLI depends on.
+var credentialFreeCommands = map[string]bool{
+	"login":  true,
+	"logout": true,
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "annuminas",
 	Short: "A CLI tool for managing Docker Hub repositories",
 	Long:  `Annuminas manages Docker Hub repositories via the Docker Hub API.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		return initClient()
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		cancelTimeout = cancel
+		cmd.SetContext(ctx)
+
+		if credentialFreeCommands[cmd.Name()] {
+			return nil
+		}
+
+		storeName, _ := cmd.Flags().GetString("credential-store")
+		maxRetries, _ := cmd.Flags().GetInt("max-retries")
+		return initClient(storeName, maxRetries)
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		if cancelTimeout != nil {
+			cancelTimeout()
+		}
 	},
 }
 
+func init() {
+	rootCmd.PersistentFlags().Duration("timeout", 30*time.Second, "Timeout for Docker Hub API requests")
+	rootCmd.PersistentFlags().Int("max-retries", 3, "Maximum retries for transport errors, 5xx, and 429 responses")
+	rootCmd.PersistentFlags().String("credential-store", "env", "Credential store backend: env, docker, or keychain")
+}
+
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
-func initClient() error {
-	// Try ~/.dotfiles/.env first, fall back to .env in current directory
-	dotfilePath := filepath.Join(os.Getenv("HOME"), ".dotfiles", ".env")
-	if _, err := os.Stat(dotfilePath); err == nil {
-		_ = godotenv.Load(dotfilePath)
-	} else {
-		_ = godotenv.Load(".env")
-	}
-
-	username := os.Getenv("DOCKERHUB_USERNAME")
-	if username == "" {
-		return fmt.Errorf("DOCKERHUB_USERNAME must be set in ~/.dotfiles/.env or .env")
+func initClient(storeName string, maxRetries int) error {
+	store, err := credentials.NewStore(storeName)
+	if err != nil {
+		return err
 	}
 
-	token := os.Getenv("DOCKERHUB_TOKEN")
-	if token == "" {
-		return fmt.Errorf("DOCKERHUB_TOKEN must be set in ~/.dotfiles/.env or .env")
+	username, token, err := store.Get(credentials.DockerHubRegistry)
+	if err != nil {
+		return err
 	}
 
-	client = dockerhub.NewClient(username, token)
+	client = dockerhub.NewClient(username, token, dockerhub.WithRetries(maxRetries))
 	return nil
 }
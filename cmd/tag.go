@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dukerupert/annuminas/pkg/dockerhub"
+	"github.com/spf13/cobra"
+)
+
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Manage Docker Hub image tags",
+}
+
+var tagListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all tags for a repository",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		if namespace == "" {
+			namespace = client.Username
+		}
+		repo, _ := cmd.Flags().GetString("repo")
+
+		tags, err := client.ListTags(cmd.Context(), namespace, repo)
+		if err != nil {
+			return err
+		}
+
+		if len(tags) == 0 {
+			fmt.Println("No tags found.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "NAME\tSIZE\tARCHITECTURES")
+		fmt.Fprintln(w, "────\t────\t─────────────")
+		for _, t := range tags {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", t.Name, t.FullSize, architectures(t))
+		}
+		return w.Flush()
+	},
+}
+
+var tagGetCmd = &cobra.Command{
+	Use:   "get <tag>",
+	Short: "Get details for a specific tag",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		if namespace == "" {
+			namespace = client.Username
+		}
+		repo, _ := cmd.Flags().GetString("repo")
+
+		tag, err := client.GetTag(cmd.Context(), namespace, repo, args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Name:      %s\n", tag.Name)
+		fmt.Printf("Full Size: %d\n", tag.FullSize)
+		fmt.Println("Images:")
+		for _, img := range tag.Images {
+			fmt.Printf("  %s/%s\tdigest=%s\tsize=%d\tlast_pushed=%s\n",
+				img.OS, img.Architecture, img.Digest, img.Size, img.LastPushed)
+		}
+		return nil
+	},
+}
+
+var tagDeleteCmd = &cobra.Command{
+	Use:   "delete <tag>",
+	Short: "Delete a tag from a repository",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		if namespace == "" {
+			namespace = client.Username
+		}
+		repo, _ := cmd.Flags().GetString("repo")
+
+		if err := client.DeleteTag(cmd.Context(), namespace, repo, args[0]); err != nil {
+			return err
+		}
+
+		fmt.Printf("Tag deleted: %s/%s:%s\n", namespace, repo, args[0])
+		return nil
+	},
+}
+
+// architectures formats the distinct architectures present across a tag's images.
+func architectures(t dockerhub.Tag) string {
+	seen := make(map[string]bool, len(t.Images))
+	archs := make([]string, 0, len(t.Images))
+	for _, img := range t.Images {
+		if seen[img.Architecture] {
+			continue
+		}
+		seen[img.Architecture] = true
+		archs = append(archs, img.Architecture)
+	}
+	return strings.Join(archs, ",")
+}
+
+func init() {
+	// Persistent flags on parent — inherited by all subcommands
+	tagCmd.PersistentFlags().String("namespace", "", "Namespace (user or org); defaults to DOCKERHUB_USERNAME")
+	tagCmd.PersistentFlags().String("repo", "", "Repository name (required)")
+	tagCmd.MarkPersistentFlagRequired("repo")
+
+	tagCmd.AddCommand(tagListCmd)
+	tagCmd.AddCommand(tagGetCmd)
+	tagCmd.AddCommand(tagDeleteCmd)
+	rootCmd.AddCommand(tagCmd)
+}
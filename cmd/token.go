@@ -25,7 +25,7 @@ Higher scopes include lower ones (e.g. repo:write implies repo:read).`,
 		label, _ := cmd.Flags().GetString("label")
 		scopes, _ := cmd.Flags().GetStringSlice("scopes")
 
-		token, err := client.CreateAccessToken(label, scopes)
+		token, err := client.CreateAccessToken(cmd.Context(), label, scopes)
 		if err != nil {
 			return err
 		}
@@ -43,7 +43,7 @@ var tokenListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all personal access tokens",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		tokens, err := client.ListAccessTokens()
+		tokens, err := client.ListAccessTokens(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -80,7 +80,7 @@ var tokenDeleteCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		uuid, _ := cmd.Flags().GetString("uuid")
 
-		if err := client.DeleteAccessToken(uuid); err != nil {
+		if err := client.DeleteAccessToken(cmd.Context(), uuid); err != nil {
 			return err
 		}
 
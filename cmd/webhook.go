@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Manage Docker Hub repository webhooks",
+}
+
+var webhookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all webhooks for a repository",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		if namespace == "" {
+			namespace = client.Username
+		}
+		repo, _ := cmd.Flags().GetString("repo")
+
+		hooks, err := client.ListWebhooks(cmd.Context(), namespace, repo)
+		if err != nil {
+			return err
+		}
+
+		if len(hooks) == 0 {
+			fmt.Println("No webhooks found.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tURL\tACTIVE\tLAST CALLED")
+		fmt.Fprintln(w, "──\t────\t───\t──────\t───────────")
+		for _, h := range hooks {
+			lastCalled := h.LastCalled
+			if lastCalled == "" {
+				lastCalled = "never"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%t\t%s\n", h.ID, h.Name, h.WebhookURL, h.Active, lastCalled)
+		}
+		return w.Flush()
+	},
+}
+
+var webhookCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a webhook on a repository",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		if namespace == "" {
+			namespace = client.Username
+		}
+		repo, _ := cmd.Flags().GetString("repo")
+		name, _ := cmd.Flags().GetString("name")
+		url, _ := cmd.Flags().GetString("url")
+
+		hook, err := client.CreateWebhook(cmd.Context(), namespace, repo, name, url)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Webhook created: %s (id=%d)\n", hook.Name, hook.ID)
+		return nil
+	},
+}
+
+var webhookDeleteCmd = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "Delete a webhook from a repository",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		if namespace == "" {
+			namespace = client.Username
+		}
+		repo, _ := cmd.Flags().GetString("repo")
+
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid webhook id %q: %w", args[0], err)
+		}
+
+		if err := client.DeleteWebhook(cmd.Context(), namespace, repo, id); err != nil {
+			return err
+		}
+
+		fmt.Printf("Webhook deleted: %s/%s (id=%d)\n", namespace, repo, id)
+		return nil
+	},
+}
+
+var webhookTestCmd = &cobra.Command{
+	Use:   "test <id>",
+	Short: "Trigger an immediate test delivery for a webhook",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		if namespace == "" {
+			namespace = client.Username
+		}
+		repo, _ := cmd.Flags().GetString("repo")
+
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid webhook id %q: %w", args[0], err)
+		}
+
+		if err := client.TestWebhook(cmd.Context(), namespace, repo, id); err != nil {
+			return err
+		}
+
+		fmt.Printf("Test delivery triggered for webhook %d\n", id)
+		return nil
+	},
+}
+
+func init() {
+	// Persistent flags on parent — inherited by all subcommands
+	webhookCmd.PersistentFlags().String("namespace", "", "Namespace (user or org); defaults to DOCKERHUB_USERNAME")
+	webhookCmd.PersistentFlags().String("repo", "", "Repository name (required)")
+	webhookCmd.MarkPersistentFlagRequired("repo")
+
+	// webhook create flags
+	webhookCreateCmd.Flags().String("name", "", "Webhook name (required)")
+	webhookCreateCmd.MarkFlagRequired("name")
+	webhookCreateCmd.Flags().String("url", "", "URL to POST to on push (required)")
+	webhookCreateCmd.MarkFlagRequired("url")
+
+	webhookCmd.AddCommand(webhookListCmd)
+	webhookCmd.AddCommand(webhookCreateCmd)
+	webhookCmd.AddCommand(webhookDeleteCmd)
+	webhookCmd.AddCommand(webhookTestCmd)
+	rootCmd.AddCommand(webhookCmd)
+}
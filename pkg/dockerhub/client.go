@@ -2,10 +2,13 @@ package dockerhub
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"sync"
 )
 
 const baseURL = "https://hub.docker.com"
@@ -14,24 +17,40 @@ const baseURL = "https://hub.docker.com"
 type Client struct {
 	Username string
 	Password string
-	jwt      string
 	http     *http.Client
+	retries  int
+	logger   *slog.Logger
+
+	mu  sync.Mutex
+	jwt string
 }
 
+// defaultMaxRetries is how many times doRequest retries a transport error,
+// 5xx response, or 429 before giving up, absent WithRetries.
+const defaultMaxRetries = 3
+
 // NewClient returns a Client authenticated with the given credentials.
 // It does NOT authenticate immediately — authentication is lazy on first API call.
-func NewClient(username, password string) *Client {
-	return &Client{
+func NewClient(username, password string, opts ...ClientOption) *Client {
+	c := &Client{
 		Username: username,
 		Password: password,
 		http:     &http.Client{},
+		retries:  defaultMaxRetries,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // --- Authentication ---
 
 // authenticate obtains a JWT from Docker Hub via POST /v2/auth/token.
-func (c *Client) authenticate() error {
+func (c *Client) authenticate(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.jwt != "" {
 		return nil
 	}
@@ -46,7 +65,7 @@ func (c *Client) authenticate() error {
 		return fmt.Errorf("encode auth request: %w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, baseURL+"/v2/auth/token", bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/v2/auth/token", bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("build auth request: %w", err)
 	}
@@ -84,78 +103,136 @@ func (c *Client) authenticate() error {
 
 // --- HTTP helpers ---
 
-func (c *Client) doRequest(method, endpoint string, body io.Reader, out any) (int, error) {
-	if err := c.authenticate(); err != nil {
+// doRequest sends a request, retrying on transport errors, 5xx responses,
+// and 429s (honoring Retry-After/X-RateLimit-Reset), and re-authenticating
+// once if the JWT has expired mid-session (401).
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, body []byte, out any) (int, error) {
+	if err := c.authenticate(ctx); err != nil {
 		return 0, err
 	}
 
-	req, err := http.NewRequest(method, baseURL+endpoint, body)
-	if err != nil {
-		return 0, fmt.Errorf("build request: %w", err)
-	}
-	req.Header.Set("Authorization", "Bearer "+c.jwt)
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
+	reauthenticated := false
 
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return 0, fmt.Errorf("http request: %w", err)
-	}
-	defer resp.Body.Close()
+	for attempt := 0; ; attempt++ {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
 
-	raw, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return resp.StatusCode, fmt.Errorf("read response: %w", err)
-	}
+		req, err := http.NewRequestWithContext(ctx, method, baseURL+endpoint, bodyReader)
+		if err != nil {
+			return 0, fmt.Errorf("build request: %w", err)
+		}
+		c.mu.Lock()
+		req.Header.Set("Authorization", "Bearer "+c.jwt)
+		c.mu.Unlock()
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
 
-	if resp.StatusCode >= 400 {
-		var apiErr errorResponse
-		if json.Unmarshal(raw, &apiErr) == nil && apiErr.text() != "" {
-			return resp.StatusCode, fmt.Errorf("docker hub api error (%s %s, status %d): %s\nresponse body: %s",
-				method, endpoint, resp.StatusCode, apiErr.text(), string(raw))
+		resp, err := c.http.Do(req)
+		if err != nil {
+			if attempt >= c.retries {
+				return 0, fmt.Errorf("http request: %w", err)
+			}
+			c.logRetry(attempt, "transport error", err)
+			if err := c.waitBackoff(ctx, attempt); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		raw, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return resp.StatusCode, fmt.Errorf("read response: %w", err)
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized && !reauthenticated:
+			reauthenticated = true
+			c.mu.Lock()
+			c.jwt = ""
+			c.mu.Unlock()
+			c.logRetry(attempt, "401 unauthorized, re-authenticating", nil)
+			if err := c.authenticate(ctx); err != nil {
+				return resp.StatusCode, err
+			}
+			continue
+
+		case resp.StatusCode == http.StatusTooManyRequests && attempt < c.retries:
+			c.logRetry(attempt, "429 rate limited", nil)
+			if err := c.waitFor(ctx, retryAfterDelay(resp.Header, attempt)); err != nil {
+				return resp.StatusCode, err
+			}
+			continue
+
+		case resp.StatusCode >= 500 && attempt < c.retries:
+			c.logRetry(attempt, fmt.Sprintf("%d server error", resp.StatusCode), nil)
+			if err := c.waitBackoff(ctx, attempt); err != nil {
+				return resp.StatusCode, err
+			}
+			continue
+
+		case resp.StatusCode >= 400:
+			var apiErr errorResponse
+			if json.Unmarshal(raw, &apiErr) == nil && apiErr.text() != "" {
+				return resp.StatusCode, fmt.Errorf("docker hub api error (%s %s, status %d): %s\nresponse body: %s",
+					method, endpoint, resp.StatusCode, apiErr.text(), string(raw))
+			}
+			return resp.StatusCode, fmt.Errorf("docker hub api error (%s %s, status %d)\nresponse body: %s",
+				method, endpoint, resp.StatusCode, string(raw))
 		}
-		return resp.StatusCode, fmt.Errorf("docker hub api error (%s %s, status %d)\nresponse body: %s",
-			method, endpoint, resp.StatusCode, string(raw))
-	}
 
-	if out != nil && len(raw) > 0 {
-		if err := json.Unmarshal(raw, out); err != nil {
-			return resp.StatusCode, fmt.Errorf("decode response: %w", err)
+		if out != nil && len(raw) > 0 {
+			if err := json.Unmarshal(raw, out); err != nil {
+				return resp.StatusCode, fmt.Errorf("decode response: %w", err)
+			}
 		}
+		return resp.StatusCode, nil
 	}
-	return resp.StatusCode, nil
 }
 
-func (c *Client) get(endpoint string, out any) error {
-	_, err := c.doRequest(http.MethodGet, endpoint, nil, out)
+func (c *Client) get(ctx context.Context, endpoint string, out any) error {
+	_, err := c.doRequest(ctx, http.MethodGet, endpoint, nil, out)
 	return err
 }
 
-func (c *Client) post(endpoint string, payload any, out any) error {
+func (c *Client) post(ctx context.Context, endpoint string, payload any, out any) error {
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("encode request: %w", err)
 	}
-	_, err = c.doRequest(http.MethodPost, endpoint, bytes.NewReader(body), out)
+	_, err = c.doRequest(ctx, http.MethodPost, endpoint, body, out)
 	return err
 }
 
-func (c *Client) delete(endpoint string) error {
-	_, err := c.doRequest(http.MethodDelete, endpoint, nil, nil)
+func (c *Client) patch(ctx context.Context, endpoint string, payload any, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode request: %w", err)
+	}
+	_, err = c.doRequest(ctx, http.MethodPatch, endpoint, body, out)
 	return err
 }
 
-func (c *Client) head(endpoint string) (int, error) {
-	if err := c.authenticate(); err != nil {
+func (c *Client) delete(ctx context.Context, endpoint string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, endpoint, nil, nil)
+	return err
+}
+
+func (c *Client) head(ctx context.Context, endpoint string) (int, error) {
+	if err := c.authenticate(ctx); err != nil {
 		return 0, err
 	}
 
-	req, err := http.NewRequest(http.MethodHead, baseURL+endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, baseURL+endpoint, nil)
 	if err != nil {
 		return 0, fmt.Errorf("build request: %w", err)
 	}
+	c.mu.Lock()
 	req.Header.Set("Authorization", "Bearer "+c.jwt)
+	c.mu.Unlock()
 
 	resp, err := c.http.Do(req)
 	if err != nil {
@@ -174,7 +251,7 @@ func (c *Client) head(endpoint string) (int, error) {
 
 // getAll fetches all pages from a paginated Docker Hub endpoint.
 // Docker Hub uses {"count", "next", "previous", "results"} format.
-func getAll[T any](c *Client, endpoint string) ([]T, error) {
+func getAll[T any](ctx context.Context, c *Client, endpoint string) ([]T, error) {
 	var all []T
 	page := 1
 
@@ -187,7 +264,7 @@ func getAll[T any](c *Client, endpoint string) ([]T, error) {
 			Previous *string `json:"previous"`
 			Results  []T     `json:"results"`
 		}
-		if err := c.get(url, &envelope); err != nil {
+		if err := c.get(ctx, url, &envelope); err != nil {
 			return nil, err
 		}
 
@@ -218,14 +295,22 @@ func (e errorResponse) text() string {
 
 // Repository represents a Docker Hub repository.
 type Repository struct {
-	Name           string `json:"name"`
-	Namespace      string `json:"namespace"`
-	Description    string `json:"description"`
-	IsPrivate      bool   `json:"is_private"`
-	StarCount      int    `json:"star_count"`
-	PullCount      int    `json:"pull_count"`
-	LastUpdated    string `json:"last_updated"`
-	DateRegistered string `json:"date_registered"`
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	Description     string `json:"description"`
+	FullDescription string `json:"full_description"`
+	IsPrivate       bool   `json:"is_private"`
+	StarCount       int    `json:"star_count"`
+	PullCount       int    `json:"pull_count"`
+	LastUpdated     string `json:"last_updated"`
+	DateRegistered  string `json:"date_registered"`
+}
+
+// RepoPatch describes a partial update to a repository's mutable fields.
+// Only non-nil fields are sent in the PATCH request.
+type RepoPatch struct {
+	Description     *string `json:"description,omitempty"`
+	FullDescription *string `json:"full_description,omitempty"`
 }
 
 // AccessToken represents a Docker Hub personal access token.
@@ -241,30 +326,55 @@ type AccessToken struct {
 	CreatorIP   string   `json:"creator_ip"`
 }
 
+// Image represents a single architecture/OS variant within a tag's manifest.
+type Image struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Digest       string `json:"digest"`
+	Size         int64  `json:"size"`
+	LastPushed   string `json:"last_pushed"`
+}
+
+// Tag represents a Docker Hub image tag.
+type Tag struct {
+	Name     string  `json:"name"`
+	FullSize int64   `json:"full_size"`
+	Images   []Image `json:"images"`
+}
+
+// Webhook represents a Docker Hub repository webhook that fires on push.
+type Webhook struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	WebhookURL string `json:"webhook_url"`
+	Active     bool   `json:"active"`
+	LastCalled string `json:"last_called"`
+}
+
 // --- API methods ---
 
 // Ping authenticates and verifies the credentials are valid.
-func (c *Client) Ping() error {
-	return c.authenticate()
+func (c *Client) Ping(ctx context.Context) error {
+	return c.authenticate(ctx)
 }
 
 // ListRepos returns all repositories in the given namespace.
-func (c *Client) ListRepos(namespace string) ([]Repository, error) {
-	return getAll[Repository](c, fmt.Sprintf("/v2/namespaces/%s/repositories", namespace))
+func (c *Client) ListRepos(ctx context.Context, namespace string) ([]Repository, error) {
+	return getAll[Repository](ctx, c, fmt.Sprintf("/v2/namespaces/%s/repositories", namespace))
 }
 
 // GetRepo returns details for a specific repository.
-func (c *Client) GetRepo(namespace, name string) (*Repository, error) {
+func (c *Client) GetRepo(ctx context.Context, namespace, name string) (*Repository, error) {
 	var repo Repository
-	if err := c.get(fmt.Sprintf("/v2/namespaces/%s/repositories/%s", namespace, name), &repo); err != nil {
+	if err := c.get(ctx, fmt.Sprintf("/v2/namespaces/%s/repositories/%s", namespace, name), &repo); err != nil {
 		return nil, err
 	}
 	return &repo, nil
 }
 
 // RepoExists checks if a repository exists (HEAD request, no body parsed).
-func (c *Client) RepoExists(namespace, name string) (bool, error) {
-	status, err := c.head(fmt.Sprintf("/v2/namespaces/%s/repositories/%s", namespace, name))
+func (c *Client) RepoExists(ctx context.Context, namespace, name string) (bool, error) {
+	status, err := c.head(ctx, fmt.Sprintf("/v2/namespaces/%s/repositories/%s", namespace, name))
 	if err != nil {
 		return false, err
 	}
@@ -272,7 +382,7 @@ func (c *Client) RepoExists(namespace, name string) (bool, error) {
 }
 
 // CreateRepo creates a new repository. Returns the created repository.
-func (c *Client) CreateRepo(namespace, name, description string, isPrivate bool) (*Repository, error) {
+func (c *Client) CreateRepo(ctx context.Context, namespace, name, description string, isPrivate bool) (*Repository, error) {
 	payload := struct {
 		Name        string `json:"name"`
 		Namespace   string `json:"namespace"`
@@ -286,15 +396,25 @@ func (c *Client) CreateRepo(namespace, name, description string, isPrivate bool)
 	}
 
 	var repo Repository
-	if err := c.post(fmt.Sprintf("/v2/namespaces/%s/repositories", namespace), payload, &repo); err != nil {
+	if err := c.post(ctx, fmt.Sprintf("/v2/namespaces/%s/repositories", namespace), payload, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// UpdateRepo applies a partial update to a repository's description and/or
+// full (markdown) description via PATCH /v2/repositories/{namespace}/{name}/.
+func (c *Client) UpdateRepo(ctx context.Context, namespace, name string, patch RepoPatch) (*Repository, error) {
+	var repo Repository
+	if err := c.patch(ctx, fmt.Sprintf("/v2/repositories/%s/%s/", namespace, name), patch, &repo); err != nil {
 		return nil, err
 	}
 	return &repo, nil
 }
 
 // EnsureRepo creates a repository if it doesn't exist. Idempotent.
-func (c *Client) EnsureRepo(namespace, name string) error {
-	exists, err := c.RepoExists(namespace, name)
+func (c *Client) EnsureRepo(ctx context.Context, namespace, name string) error {
+	exists, err := c.RepoExists(ctx, namespace, name)
 	if err != nil {
 		return fmt.Errorf("check repo existence: %w", err)
 	}
@@ -302,7 +422,7 @@ func (c *Client) EnsureRepo(namespace, name string) error {
 		return nil
 	}
 
-	_, err = c.CreateRepo(namespace, name, "", false)
+	_, err = c.CreateRepo(ctx, namespace, name, "", false)
 	if err != nil {
 		return fmt.Errorf("create repo: %w", err)
 	}
@@ -310,14 +430,67 @@ func (c *Client) EnsureRepo(namespace, name string) error {
 }
 
 // DeleteRepo deletes a repository by name.
-func (c *Client) DeleteRepo(namespace, name string) error {
-	return c.delete(fmt.Sprintf("/v2/namespaces/%s/repositories/%s", namespace, name))
+func (c *Client) DeleteRepo(ctx context.Context, namespace, name string) error {
+	return c.delete(ctx, fmt.Sprintf("/v2/namespaces/%s/repositories/%s", namespace, name))
+}
+
+// ListTags returns all tags for a repository.
+func (c *Client) ListTags(ctx context.Context, namespace, name string) ([]Tag, error) {
+	return getAll[Tag](ctx, c, fmt.Sprintf("/v2/namespaces/%s/repositories/%s/tags", namespace, name))
+}
+
+// GetTag returns details for a specific tag.
+func (c *Client) GetTag(ctx context.Context, namespace, name, tag string) (*Tag, error) {
+	var t Tag
+	if err := c.get(ctx, fmt.Sprintf("/v2/namespaces/%s/repositories/%s/tags/%s", namespace, name, tag), &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// DeleteTag deletes a tag from a repository.
+func (c *Client) DeleteTag(ctx context.Context, namespace, name, tag string) error {
+	return c.delete(ctx, fmt.Sprintf("/v2/namespaces/%s/repositories/%s/tags/%s", namespace, name, tag))
+}
+
+// ListWebhooks returns all webhooks configured for a repository.
+func (c *Client) ListWebhooks(ctx context.Context, namespace, name string) ([]Webhook, error) {
+	return getAll[Webhook](ctx, c, fmt.Sprintf("/v2/repositories/%s/%s/webhook_pipeline/", namespace, name))
+}
+
+// CreateWebhook creates a webhook on a repository that POSTs to webhookURL on push.
+func (c *Client) CreateWebhook(ctx context.Context, namespace, name, webhookName, webhookURL string) (*Webhook, error) {
+	payload := struct {
+		Name       string `json:"name"`
+		WebhookURL string `json:"webhook_url"`
+	}{
+		Name:       webhookName,
+		WebhookURL: webhookURL,
+	}
+
+	var wh Webhook
+	if err := c.post(ctx, fmt.Sprintf("/v2/repositories/%s/%s/webhook_pipeline/", namespace, name), payload, &wh); err != nil {
+		return nil, err
+	}
+	return &wh, nil
+}
+
+// DeleteWebhook deletes a webhook from a repository by ID.
+func (c *Client) DeleteWebhook(ctx context.Context, namespace, name string, id int) error {
+	return c.delete(ctx, fmt.Sprintf("/v2/repositories/%s/%s/webhook_pipeline/%d/", namespace, name, id))
+}
+
+// TestWebhook triggers an immediate "call now" delivery for a webhook, so an
+// operator can verify its configuration without waiting for a real push.
+func (c *Client) TestWebhook(ctx context.Context, namespace, name string, id int) error {
+	_, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/v2/repositories/%s/%s/webhook_pipeline/%d/hooks/", namespace, name, id), nil, nil)
+	return err
 }
 
 // CreateAccessToken creates a personal access token with the given label and scopes.
 // Valid scopes: "repo:admin", "repo:write", "repo:read", "repo:public_read".
 // The token value is only available in the response from creation — it cannot be retrieved later.
-func (c *Client) CreateAccessToken(label string, scopes []string) (*AccessToken, error) {
+func (c *Client) CreateAccessToken(ctx context.Context, label string, scopes []string) (*AccessToken, error) {
 	payload := struct {
 		TokenLabel string   `json:"token_label"`
 		Scopes     []string `json:"scopes"`
@@ -327,18 +500,18 @@ func (c *Client) CreateAccessToken(label string, scopes []string) (*AccessToken,
 	}
 
 	var token AccessToken
-	if err := c.post("/v2/access-tokens", payload, &token); err != nil {
+	if err := c.post(ctx, "/v2/access-tokens", payload, &token); err != nil {
 		return nil, err
 	}
 	return &token, nil
 }
 
 // ListAccessTokens returns all personal access tokens for the authenticated user.
-func (c *Client) ListAccessTokens() ([]AccessToken, error) {
-	return getAll[AccessToken](c, "/v2/access-tokens")
+func (c *Client) ListAccessTokens(ctx context.Context) ([]AccessToken, error) {
+	return getAll[AccessToken](ctx, c, "/v2/access-tokens")
 }
 
 // DeleteAccessToken deletes a personal access token by UUID.
-func (c *Client) DeleteAccessToken(uuid string) error {
-	return c.delete(fmt.Sprintf("/v2/access-tokens/%s", uuid))
+func (c *Client) DeleteAccessToken(ctx context.Context, uuid string) error {
+	return c.delete(ctx, fmt.Sprintf("/v2/access-tokens/%s", uuid))
 }
@@ -0,0 +1,289 @@
+package dockerhub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestServer wires a handler that always serves /v2/auth/token, plus
+// whatever behavior handle provides for the endpoint under test.
+func newTestServer(t *testing.T, handle http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "test-jwt"})
+	})
+	mux.HandleFunc("/", handle)
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// redirectTransport rewrites every outgoing request to target's scheme and
+// host, so a Client built against the real baseURL constant can be pointed
+// at an httptest.Server via a fake http.RoundTripper.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server, opts ...ClientOption) *Client {
+	t.Helper()
+
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("parse test server URL: %v", err)
+	}
+
+	opts = append([]ClientOption{WithHTTPClient(&http.Client{Transport: &redirectTransport{target: target}})}, opts...)
+	return NewClient("user", "pass", opts...)
+}
+
+func TestDoRequest_Retries(t *testing.T) {
+	tests := []struct {
+		name       string
+		handle     func(t *testing.T, calls *int32) http.HandlerFunc
+		wantStatus int
+		wantErr    bool
+		minCalls   int32
+	}{
+		{
+			name: "401 then 200 re-authenticates once",
+			handle: func(t *testing.T, calls *int32) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					n := atomic.AddInt32(calls, 1)
+					if n == 1 {
+						w.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+					_ = json.NewEncoder(w).Encode(map[string]string{"name": "ok"})
+				}
+			},
+			wantStatus: http.StatusOK,
+			minCalls:   2,
+		},
+		{
+			name: "429 with Retry-After then 200",
+			handle: func(t *testing.T, calls *int32) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					n := atomic.AddInt32(calls, 1)
+					if n == 1 {
+						w.Header().Set("Retry-After", "0")
+						w.WriteHeader(http.StatusTooManyRequests)
+						return
+					}
+					_ = json.NewEncoder(w).Encode(map[string]string{"name": "ok"})
+				}
+			},
+			wantStatus: http.StatusOK,
+			minCalls:   2,
+		},
+		{
+			name: "500 then 200",
+			handle: func(t *testing.T, calls *int32) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					n := atomic.AddInt32(calls, 1)
+					if n == 1 {
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+					_ = json.NewEncoder(w).Encode(map[string]string{"name": "ok"})
+				}
+			},
+			wantStatus: http.StatusOK,
+			minCalls:   2,
+		},
+		{
+			name: "exhausts retries on persistent 500",
+			handle: func(t *testing.T, calls *int32) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					atomic.AddInt32(calls, 1)
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			},
+			wantErr:  true,
+			minCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := newTestServer(t, tt.handle(t, &calls))
+			c := newTestClient(t, srv, WithRetries(2))
+
+			var repo Repository
+			status, err := c.doRequest(context.Background(), http.MethodGet, "/v2/namespaces/acme/repositories/widgets", nil, &repo)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if status != tt.wantStatus {
+					t.Errorf("status = %d, want %d", status, tt.wantStatus)
+				}
+			}
+
+			if atomic.LoadInt32(&calls) < tt.minCalls {
+				t.Errorf("calls = %d, want at least %d", calls, tt.minCalls)
+			}
+		})
+	}
+}
+
+func TestBackoffDelay_Bounded(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		d := backoffDelay(attempt)
+		if d < 0 || d > maxRetryDelay {
+			t.Errorf("backoffDelay(%d) = %v, want within [0, %v]", attempt, d, maxRetryDelay)
+		}
+	}
+}
+
+func TestRetryAfterDelay_Seconds(t *testing.T) {
+	h := http.Header{}
+	h.Set("Retry-After", "2")
+
+	d := retryAfterDelay(h, 0)
+	if d != 2*time.Second {
+		t.Errorf("retryAfterDelay = %v, want 2s", d)
+	}
+}
+
+// TestDoRequest_RedirectDowngradesMethod documents why every mutating
+// request against a Docker Hub collection endpoint must carry a trailing
+// slash: Go's default http.Client follows a 301 to a non-slash-terminated
+// POST by silently replaying it as a GET, so a missing slash turns a write
+// into a no-op rather than an error.
+func TestDoRequest_RedirectDowngradesMethod(t *testing.T) {
+	var gotMethods []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "test-jwt"})
+	})
+	mux.HandleFunc("/v2/repositories/acme/widgets/webhook_pipeline", func(w http.ResponseWriter, r *http.Request) {
+		gotMethods = append(gotMethods, r.Method)
+		http.Redirect(w, r, r.URL.Path+"/", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/v2/repositories/acme/widgets/webhook_pipeline/", func(w http.ResponseWriter, r *http.Request) {
+		gotMethods = append(gotMethods, r.Method)
+		_ = json.NewEncoder(w).Encode(map[string]string{"name": "ok"})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	c := newTestClient(t, srv)
+
+	if _, err := c.doRequest(context.Background(), http.MethodPost, "/v2/repositories/acme/widgets/webhook_pipeline", []byte(`{}`), nil); err != nil {
+		t.Fatalf("doRequest: %v", err)
+	}
+
+	if len(gotMethods) != 2 || gotMethods[0] != http.MethodPost || gotMethods[1] != http.MethodGet {
+		t.Fatalf("methods seen = %v, want [POST GET] (redirect silently downgrades the write)", gotMethods)
+	}
+}
+
+// TestWebhookEndpoints_TrailingSlash verifies the webhook methods hit their
+// slash-terminated endpoints directly, without relying on a redirect that
+// would (per TestDoRequest_RedirectDowngradesMethod) silently downgrade the
+// request.
+func TestWebhookEndpoints_TrailingSlash(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		method string
+		call   func(t *testing.T, c *Client)
+	}{
+		{
+			name:   "ListWebhooks",
+			path:   "/v2/repositories/acme/widgets/webhook_pipeline/",
+			method: http.MethodGet,
+			call: func(t *testing.T, c *Client) {
+				if _, err := c.ListWebhooks(context.Background(), "acme", "widgets"); err != nil {
+					t.Fatalf("ListWebhooks: %v", err)
+				}
+			},
+		},
+		{
+			name:   "CreateWebhook",
+			path:   "/v2/repositories/acme/widgets/webhook_pipeline/",
+			method: http.MethodPost,
+			call: func(t *testing.T, c *Client) {
+				if _, err := c.CreateWebhook(context.Background(), "acme", "widgets", "deploy", "https://example.com/hook"); err != nil {
+					t.Fatalf("CreateWebhook: %v", err)
+				}
+			},
+		},
+		{
+			name:   "DeleteWebhook",
+			path:   "/v2/repositories/acme/widgets/webhook_pipeline/7/",
+			method: http.MethodDelete,
+			call: func(t *testing.T, c *Client) {
+				if err := c.DeleteWebhook(context.Background(), "acme", "widgets", 7); err != nil {
+					t.Fatalf("DeleteWebhook: %v", err)
+				}
+			},
+		},
+		{
+			name:   "TestWebhook",
+			path:   "/v2/repositories/acme/widgets/webhook_pipeline/7/hooks/",
+			method: http.MethodPost,
+			call: func(t *testing.T, c *Client) {
+				if err := c.TestWebhook(context.Background(), "acme", "widgets", 7); err != nil {
+					t.Fatalf("TestWebhook: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v2/auth/token", func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "test-jwt"})
+			})
+			mux.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				if r.Method != tt.method {
+					t.Errorf("method = %s, want %s", r.Method, tt.method)
+				}
+				switch {
+				case r.Method == http.MethodGet:
+					_ = json.NewEncoder(w).Encode(map[string]any{"count": 0, "next": nil, "previous": nil, "results": []Webhook{}})
+				case r.Method == http.MethodPost:
+					_ = json.NewEncoder(w).Encode(Webhook{ID: 7, Name: "deploy"})
+				}
+			})
+
+			srv := httptest.NewServer(mux)
+			t.Cleanup(srv.Close)
+			c := newTestClient(t, srv)
+
+			tt.call(t, c)
+
+			if !called {
+				t.Fatalf("no request reached %s — endpoint construction is wrong", tt.path)
+			}
+		})
+	}
+}
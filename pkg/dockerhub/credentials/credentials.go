@@ -0,0 +1,36 @@
+// Package credentials provides pluggable storage backends for Docker Hub
+// credentials: the existing env/.env loader, the docker CLI's config.json
+// (including credsStore/credHelpers), and the OS keychain.
+package credentials
+
+import "fmt"
+
+// DockerHubRegistry is the registry key Docker Hub credentials are filed
+// under in ~/.docker/config.json and the OS keychain, matching what
+// `docker login` itself uses.
+const DockerHubRegistry = "https://index.docker.io/v1/"
+
+// Store reads, writes, and removes credentials for a registry.
+type Store interface {
+	// Get returns the username and secret stored for registry.
+	Get(registry string) (username, secret string, err error)
+	// Set stores username and secret for registry, overwriting any existing entry.
+	Set(registry, username, secret string) error
+	// Delete removes any stored entry for registry.
+	Delete(registry string) error
+}
+
+// NewStore returns the Store backend named by kind: "env", "docker", or
+// "keychain".
+func NewStore(kind string) (Store, error) {
+	switch kind {
+	case "env", "":
+		return NewEnvStore(""), nil
+	case "docker":
+		return NewDockerConfigStore("")
+	case "keychain":
+		return NewKeyringStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown credential store %q (want env, docker, or keychain)", kind)
+	}
+}
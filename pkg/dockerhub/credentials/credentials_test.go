@@ -0,0 +1,75 @@
+package credentials
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvStore_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	store := NewEnvStore(path)
+
+	// godotenv.Load sets real process environment variables, so clear them
+	// between steps to exercise the file rather than a prior Load's residue.
+	clearEnv := func() {
+		os.Unsetenv("DOCKERHUB_USERNAME")
+		os.Unsetenv("DOCKERHUB_TOKEN")
+	}
+	clearEnv()
+	t.Cleanup(clearEnv)
+
+	if err := store.Set("ignored", "alice", "s3cr3t"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	username, secret, err := store.Get("ignored")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if username != "alice" || secret != "s3cr3t" {
+		t.Errorf("Get = (%q, %q), want (alice, s3cr3t)", username, secret)
+	}
+
+	if err := store.Delete("ignored"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	clearEnv()
+	if _, _, err := store.Get("ignored"); err == nil {
+		t.Errorf("Get after Delete: expected an error, got none")
+	}
+}
+
+func TestDockerConfigStore_InlineAuthRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	store, err := NewDockerConfigStore(path)
+	if err != nil {
+		t.Fatalf("NewDockerConfigStore: %v", err)
+	}
+
+	if err := store.Set(DockerHubRegistry, "bob", "hunter2"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	username, secret, err := store.Get(DockerHubRegistry)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if username != "bob" || secret != "hunter2" {
+		t.Errorf("Get = (%q, %q), want (bob, hunter2)", username, secret)
+	}
+
+	if err := store.Delete(DockerHubRegistry); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, _, err := store.Get(DockerHubRegistry); err == nil {
+		t.Errorf("Get after Delete: expected an error, got none")
+	}
+}
+
+func TestNewStore_UnknownKind(t *testing.T) {
+	if _, err := NewStore("carrier-pigeon"); err == nil {
+		t.Error("expected an error for an unknown store kind, got none")
+	}
+}
@@ -0,0 +1,195 @@
+package credentials
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// dockerConfig mirrors the subset of ~/.docker/config.json this package
+// understands: static auths plus the credsStore/credHelpers indirection to
+// an external docker-credential-<name> helper.
+type dockerConfig struct {
+	Auths       map[string]dockerAuthEntry `json:"auths"`
+	CredsStore  string                     `json:"credsStore,omitempty"`
+	CredHelpers map[string]string          `json:"credHelpers,omitempty"`
+}
+
+type dockerAuthEntry struct {
+	Auth string `json:"auth,omitempty"`
+}
+
+// DockerConfigStore reads and writes credentials via ~/.docker/config.json,
+// reusing whatever identity a prior `docker login` already established.
+type DockerConfigStore struct {
+	path string
+}
+
+// NewDockerConfigStore returns a DockerConfigStore backed by path. An empty
+// path resolves to ~/.docker/config.json.
+func NewDockerConfigStore(path string) (*DockerConfigStore, error) {
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("resolve home directory: %w", err)
+		}
+		path = filepath.Join(home, ".docker", "config.json")
+	}
+	return &DockerConfigStore{path: path}, nil
+}
+
+func (s *DockerConfigStore) load() (*dockerConfig, error) {
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &dockerConfig{Auths: map[string]dockerAuthEntry{}}, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", s.path, err)
+	}
+
+	var cfg dockerConfig
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", s.path, err)
+	}
+	if cfg.Auths == nil {
+		cfg.Auths = map[string]dockerAuthEntry{}
+	}
+	return &cfg, nil
+}
+
+func (s *DockerConfigStore) save(cfg *dockerConfig) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(s.path), err)
+	}
+	raw, err := json.MarshalIndent(cfg, "", "\t")
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", s.path, err)
+	}
+	return os.WriteFile(s.path, raw, 0o600)
+}
+
+// helperFor returns the docker-credential-<name> binary responsible for
+// registry, preferring a per-registry credHelpers entry over the global
+// credsStore, and "" if neither applies.
+func (cfg *dockerConfig) helperFor(registry string) string {
+	if name, ok := cfg.CredHelpers[registry]; ok {
+		return name
+	}
+	return cfg.CredsStore
+}
+
+// Get returns the credentials for registry, via the configured credential
+// helper if one applies, otherwise by decoding the inline "auth" field.
+func (s *DockerConfigStore) Get(registry string) (username, secret string, err error) {
+	cfg, err := s.load()
+	if err != nil {
+		return "", "", err
+	}
+
+	if helper := cfg.helperFor(registry); helper != "" {
+		return runCredentialHelper(helper, "get", registry)
+	}
+
+	entry, ok := cfg.Auths[registry]
+	if !ok || entry.Auth == "" {
+		return "", "", fmt.Errorf("no credentials for %s in %s", registry, s.path)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+	if err != nil {
+		return "", "", fmt.Errorf("decode auth for %s: %w", registry, err)
+	}
+	username, secret, ok = strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", "", fmt.Errorf("malformed auth entry for %s", registry)
+	}
+	return username, secret, nil
+}
+
+// Set stores username/secret for registry via the configured credential
+// helper if one applies, otherwise inline (base64) in config.json.
+func (s *DockerConfigStore) Set(registry, username, secret string) error {
+	cfg, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	if helper := cfg.helperFor(registry); helper != "" {
+		return storeCredentialHelper(helper, registry, username, secret)
+	}
+
+	auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + secret))
+	cfg.Auths[registry] = dockerAuthEntry{Auth: auth}
+	return s.save(cfg)
+}
+
+// Delete removes any stored entry for registry, via the configured
+// credential helper if one applies, otherwise from config.json.
+func (s *DockerConfigStore) Delete(registry string) error {
+	cfg, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	if helper := cfg.helperFor(registry); helper != "" {
+		return eraseCredentialHelper(helper, registry)
+	}
+
+	delete(cfg.Auths, registry)
+	return s.save(cfg)
+}
+
+// credHelperOutput is the JSON shape docker-credential-<name> helpers emit
+// on "get" and consume on "store", per the docker-credential-helper protocol.
+type credHelperOutput struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+func runCredentialHelper(helper, op, registry string) (username, secret string, err error) {
+	out, err := execCredentialHelper(helper, op, strings.NewReader(registry+"\n"))
+	if err != nil {
+		return "", "", err
+	}
+
+	var resp credHelperOutput
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return "", "", fmt.Errorf("parse docker-credential-%s output: %w", helper, err)
+	}
+	return resp.Username, resp.Secret, nil
+}
+
+func storeCredentialHelper(helper, registry, username, secret string) error {
+	req, err := json.Marshal(credHelperOutput{ServerURL: registry, Username: username, Secret: secret})
+	if err != nil {
+		return fmt.Errorf("encode docker-credential-%s input: %w", helper, err)
+	}
+	_, err = execCredentialHelper(helper, "store", bytes.NewReader(req))
+	return err
+}
+
+func eraseCredentialHelper(helper, registry string) error {
+	_, err := execCredentialHelper(helper, "erase", strings.NewReader(registry+"\n"))
+	return err
+}
+
+func execCredentialHelper(helper, op string, stdin io.Reader) ([]byte, error) {
+	cmd := exec.Command("docker-credential-"+helper, op)
+	cmd.Stdin = stdin
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("docker-credential-%s %s: %w: %s", helper, op, err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
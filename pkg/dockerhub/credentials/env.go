@@ -0,0 +1,86 @@
+package credentials
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/joho/godotenv"
+)
+
+// EnvStore reads DOCKERHUB_USERNAME/DOCKERHUB_TOKEN from the process
+// environment, loading them from a dotenv file first. It ignores the
+// registry argument — it only ever knows about one pair of credentials.
+type EnvStore struct {
+	// path is the dotenv file Set/Delete rewrite. Get also loads it (via
+	// godotenv, which does not override already-set environment variables).
+	path string
+}
+
+// NewEnvStore returns an EnvStore backed by path. An empty path resolves to
+// ~/.dotfiles/.env if present, falling back to .env in the working directory.
+func NewEnvStore(path string) *EnvStore {
+	if path == "" {
+		path = defaultEnvPath()
+	}
+	return &EnvStore{path: path}
+}
+
+func defaultEnvPath() string {
+	dotfilePath := filepath.Join(os.Getenv("HOME"), ".dotfiles", ".env")
+	if _, err := os.Stat(dotfilePath); err == nil {
+		return dotfilePath
+	}
+	return ".env"
+}
+
+// Get returns the credentials from the process environment, loading them
+// from the dotenv file first.
+func (s *EnvStore) Get(registry string) (username, secret string, err error) {
+	_ = godotenv.Load(s.path)
+
+	username = os.Getenv("DOCKERHUB_USERNAME")
+	if username == "" {
+		return "", "", fmt.Errorf("DOCKERHUB_USERNAME must be set in %s or the environment", s.path)
+	}
+
+	secret = os.Getenv("DOCKERHUB_TOKEN")
+	if secret == "" {
+		return "", "", fmt.Errorf("DOCKERHUB_TOKEN must be set in %s or the environment", s.path)
+	}
+
+	return username, secret, nil
+}
+
+// Set writes DOCKERHUB_USERNAME and DOCKERHUB_TOKEN into the dotenv file,
+// replacing any existing values for those keys.
+func (s *EnvStore) Set(registry, username, secret string) error {
+	env, err := godotenv.Read(s.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		// A missing file is fine — we're creating it.
+		env = map[string]string{}
+	}
+	env["DOCKERHUB_USERNAME"] = username
+	env["DOCKERHUB_TOKEN"] = secret
+	return godotenv.Write(env, s.path)
+}
+
+// Delete removes DOCKERHUB_USERNAME and DOCKERHUB_TOKEN from the dotenv file.
+func (s *EnvStore) Delete(registry string) error {
+	env, err := godotenv.Read(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	delete(env, "DOCKERHUB_USERNAME")
+	delete(env, "DOCKERHUB_TOKEN")
+	if len(env) == 0 {
+		return os.Remove(s.path)
+	}
+	return godotenv.Write(env, s.path)
+}
@@ -0,0 +1,63 @@
+package credentials
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keyringService namespaces every entry this package writes to the OS
+// keychain so it doesn't collide with other keychain users.
+const keyringService = "annuminas"
+
+// KeyringStore stores credentials in the OS keychain (macOS Keychain,
+// Windows Credential Manager, or a Secret Service-compatible Linux keyring).
+type KeyringStore struct{}
+
+// NewKeyringStore returns a KeyringStore.
+func NewKeyringStore() *KeyringStore {
+	return &KeyringStore{}
+}
+
+// keyringEntry is the JSON payload stored under each registry's keyring
+// entry — the keyring API only stores a single secret string per key, so
+// username and secret are packed together.
+type keyringEntry struct {
+	Username string `json:"username"`
+	Secret   string `json:"secret"`
+}
+
+// Get returns the credentials for registry from the OS keychain.
+func (s *KeyringStore) Get(registry string) (username, secret string, err error) {
+	raw, err := keyring.Get(keyringService, registry)
+	if err != nil {
+		return "", "", fmt.Errorf("get %s from keychain: %w", registry, err)
+	}
+
+	var entry keyringEntry
+	if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+		return "", "", fmt.Errorf("decode keychain entry for %s: %w", registry, err)
+	}
+	return entry.Username, entry.Secret, nil
+}
+
+// Set stores username/secret for registry in the OS keychain.
+func (s *KeyringStore) Set(registry, username, secret string) error {
+	raw, err := json.Marshal(keyringEntry{Username: username, Secret: secret})
+	if err != nil {
+		return fmt.Errorf("encode keychain entry for %s: %w", registry, err)
+	}
+	if err := keyring.Set(keyringService, registry, string(raw)); err != nil {
+		return fmt.Errorf("set %s in keychain: %w", registry, err)
+	}
+	return nil
+}
+
+// Delete removes any stored entry for registry from the OS keychain.
+func (s *KeyringStore) Delete(registry string) error {
+	if err := keyring.Delete(keyringService, registry); err != nil {
+		return fmt.Errorf("delete %s from keychain: %w", registry, err)
+	}
+	return nil
+}
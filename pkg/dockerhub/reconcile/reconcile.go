@@ -0,0 +1,344 @@
+// Package reconcile implements declarative reconciliation of Docker Hub
+// repositories, access tokens, and webhooks against a YAML manifest.
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/dukerupert/annuminas/pkg/dockerhub"
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest describes the desired state of a namespace's Docker Hub objects.
+type Manifest struct {
+	Repositories []RepositorySpec  `yaml:"repositories"`
+	AccessTokens []AccessTokenSpec `yaml:"access_tokens"`
+}
+
+// RepositorySpec describes the desired state of a single repository.
+//
+// Description is a pointer so an omitted field in the manifest (leave
+// whatever is on Docker Hub alone) is distinguishable from an explicit
+// empty string (clear the description).
+type RepositorySpec struct {
+	Name            string        `yaml:"name"`
+	Private         bool          `yaml:"private"`
+	Description     *string       `yaml:"description"`
+	FullDescription string        `yaml:"full_description"`
+	ReadmeFile      string        `yaml:"readme_file"`
+	Webhooks        []WebhookSpec `yaml:"webhooks"`
+}
+
+// WebhookSpec describes the desired state of a single webhook on a
+// repository. Webhooks are matched by name — Docker Hub has no other stable
+// identifier.
+type WebhookSpec struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+}
+
+// AccessTokenSpec describes the desired state of a single access token.
+// Tokens are matched by label — Docker Hub has no other stable identifier.
+type AccessTokenSpec struct {
+	Label  string   `yaml:"label"`
+	Scopes []string `yaml:"scopes"`
+}
+
+// ParseManifest reads and parses a YAML manifest from path.
+func ParseManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// Diff records what reconciliation did (or, with DryRun, would do) to each
+// object, identified as "<kind>/<name>".
+type Diff struct {
+	Created   []string
+	Updated   []string
+	Unchanged []string
+	Deleted   []string
+}
+
+// Config controls how Apply reconciles a Manifest.
+type Config struct {
+	// DryRun reports the plan without mutating anything.
+	DryRun bool
+	// Prune deletes repositories and access tokens present in the namespace
+	// but absent from the manifest, and webhooks absent from the manifest on
+	// repositories that were already on Docker Hub.
+	Prune bool
+	// SecretWriter receives the generated secret for each newly created
+	// access token, since Docker Hub never returns it again. Defaults to
+	// os.Stdout when nil.
+	SecretWriter io.Writer
+}
+
+// Apply reconciles the namespace's repositories and access tokens to match
+// manifest, returning a structured diff of what changed.
+func Apply(ctx context.Context, client *dockerhub.Client, namespace string, manifest *Manifest, cfg Config) (*Diff, error) {
+	diff := &Diff{}
+
+	if err := applyRepositories(ctx, client, namespace, manifest.Repositories, cfg, diff); err != nil {
+		return diff, err
+	}
+	if err := applyAccessTokens(ctx, client, manifest.AccessTokens, cfg, diff); err != nil {
+		return diff, err
+	}
+
+	return diff, nil
+}
+
+func applyRepositories(ctx context.Context, client *dockerhub.Client, namespace string, specs []RepositorySpec, cfg Config, diff *Diff) error {
+	wanted := make(map[string]bool, len(specs))
+
+	for _, spec := range specs {
+		wanted[spec.Name] = true
+		kind := "repository/" + spec.Name
+
+		fullDescription := spec.FullDescription
+		if spec.ReadmeFile != "" {
+			data, err := os.ReadFile(spec.ReadmeFile)
+			if err != nil {
+				return fmt.Errorf("%s: read readme_file: %w", spec.Name, err)
+			}
+			fullDescription = string(data)
+		}
+
+		exists, err := client.RepoExists(ctx, namespace, spec.Name)
+		if err != nil {
+			return fmt.Errorf("%s: check existence: %w", spec.Name, err)
+		}
+
+		if !exists {
+			diff.Created = append(diff.Created, kind)
+			if !cfg.DryRun {
+				description := ""
+				if spec.Description != nil {
+					description = *spec.Description
+				}
+				if _, err := client.CreateRepo(ctx, namespace, spec.Name, description, spec.Private); err != nil {
+					return fmt.Errorf("%s: create: %w", spec.Name, err)
+				}
+				if fullDescription != "" {
+					if _, err := client.UpdateRepo(ctx, namespace, spec.Name, dockerhub.RepoPatch{FullDescription: &fullDescription}); err != nil {
+						return fmt.Errorf("%s: set full description: %w", spec.Name, err)
+					}
+				}
+			}
+			if err := applyWebhooks(ctx, client, namespace, spec.Name, spec.Webhooks, true, cfg, diff); err != nil {
+				return err
+			}
+			continue
+		}
+
+		current, err := client.GetRepo(ctx, namespace, spec.Name)
+		if err != nil {
+			return fmt.Errorf("%s: get: %w", spec.Name, err)
+		}
+
+		patch := dockerhub.RepoPatch{}
+		drift := false
+		if spec.Description != nil && *spec.Description != current.Description {
+			patch.Description = spec.Description
+			drift = true
+		}
+		if fullDescription != "" && fullDescription != current.FullDescription {
+			patch.FullDescription = &fullDescription
+			drift = true
+		}
+
+		if !drift {
+			diff.Unchanged = append(diff.Unchanged, kind)
+		} else {
+			diff.Updated = append(diff.Updated, kind)
+			if !cfg.DryRun {
+				if _, err := client.UpdateRepo(ctx, namespace, spec.Name, patch); err != nil {
+					return fmt.Errorf("%s: update: %w", spec.Name, err)
+				}
+			}
+		}
+
+		if err := applyWebhooks(ctx, client, namespace, spec.Name, spec.Webhooks, false, cfg, diff); err != nil {
+			return err
+		}
+	}
+
+	if !cfg.Prune {
+		return nil
+	}
+
+	existing, err := client.ListRepos(ctx, namespace)
+	if err != nil {
+		return fmt.Errorf("list repositories for prune: %w", err)
+	}
+	for _, repo := range existing {
+		if wanted[repo.Name] {
+			continue
+		}
+		diff.Deleted = append(diff.Deleted, "repository/"+repo.Name)
+		if cfg.DryRun {
+			continue
+		}
+		if err := client.DeleteRepo(ctx, namespace, repo.Name); err != nil {
+			return fmt.Errorf("%s: prune: %w", repo.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyWebhooks reconciles a single repository's webhooks. repoIsNew skips
+// ListWebhooks (and pruning) for a repository created earlier in this same
+// Apply call, since it cannot yet have any webhooks on Docker Hub.
+func applyWebhooks(ctx context.Context, client *dockerhub.Client, namespace, repoName string, specs []WebhookSpec, repoIsNew bool, cfg Config, diff *Diff) error {
+	var existing []dockerhub.Webhook
+	if !repoIsNew {
+		var err error
+		existing, err = client.ListWebhooks(ctx, namespace, repoName)
+		if err != nil {
+			return fmt.Errorf("%s: list webhooks: %w", repoName, err)
+		}
+	}
+
+	byName := make(map[string]dockerhub.Webhook, len(existing))
+	for _, wh := range existing {
+		byName[wh.Name] = wh
+	}
+
+	wanted := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		wanted[spec.Name] = true
+		kind := fmt.Sprintf("webhook/%s/%s", repoName, spec.Name)
+
+		if _, ok := byName[spec.Name]; ok {
+			// Docker Hub webhooks have no update endpoint this client can
+			// drift-detect against beyond existence, so a match by name is
+			// always unchanged.
+			diff.Unchanged = append(diff.Unchanged, kind)
+			continue
+		}
+
+		diff.Created = append(diff.Created, kind)
+		if cfg.DryRun {
+			continue
+		}
+		if _, err := client.CreateWebhook(ctx, namespace, repoName, spec.Name, spec.URL); err != nil {
+			return fmt.Errorf("%s: create webhook %s: %w", repoName, spec.Name, err)
+		}
+	}
+
+	if !cfg.Prune || repoIsNew {
+		return nil
+	}
+
+	for _, wh := range existing {
+		if wanted[wh.Name] {
+			continue
+		}
+		diff.Deleted = append(diff.Deleted, fmt.Sprintf("webhook/%s/%s", repoName, wh.Name))
+		if cfg.DryRun {
+			continue
+		}
+		if err := client.DeleteWebhook(ctx, namespace, repoName, wh.ID); err != nil {
+			return fmt.Errorf("%s: prune webhook %s: %w", repoName, wh.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func applyAccessTokens(ctx context.Context, client *dockerhub.Client, specs []AccessTokenSpec, cfg Config, diff *Diff) error {
+	existing, err := client.ListAccessTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("list access tokens: %w", err)
+	}
+
+	byLabel := make(map[string]dockerhub.AccessToken, len(existing))
+	for _, t := range existing {
+		byLabel[t.TokenLabel] = t
+	}
+
+	wanted := make(map[string]bool, len(specs))
+	secretOut := cfg.SecretWriter
+	if secretOut == nil {
+		secretOut = os.Stdout
+	}
+
+	for _, spec := range specs {
+		wanted[spec.Label] = true
+		kind := "access_token/" + spec.Label
+
+		if _, ok := byLabel[spec.Label]; ok {
+			// Docker Hub access tokens have no mutable fields this client can
+			// drift-detect beyond label, so a match is always unchanged.
+			diff.Unchanged = append(diff.Unchanged, kind)
+			continue
+		}
+
+		diff.Created = append(diff.Created, kind)
+		if cfg.DryRun {
+			continue
+		}
+		token, err := client.CreateAccessToken(ctx, spec.Label, spec.Scopes)
+		if err != nil {
+			return fmt.Errorf("%s: create: %w", spec.Label, err)
+		}
+		fmt.Fprintf(secretOut, "%s\t%s\n", spec.Label, token.Token)
+	}
+
+	if !cfg.Prune {
+		return nil
+	}
+
+	for _, t := range existing {
+		if wanted[t.TokenLabel] {
+			continue
+		}
+		diff.Deleted = append(diff.Deleted, "access_token/"+t.TokenLabel)
+		if cfg.DryRun {
+			continue
+		}
+		if err := client.DeleteAccessToken(ctx, t.UUID); err != nil {
+			return fmt.Errorf("%s: prune: %w", t.TokenLabel, err)
+		}
+	}
+
+	return nil
+}
+
+// Print writes a human-readable summary of the diff to w.
+func (d *Diff) Print(w io.Writer) {
+	sections := []struct {
+		label string
+		items []string
+	}{
+		{"Created", d.Created},
+		{"Updated", d.Updated},
+		{"Unchanged", d.Unchanged},
+		{"Deleted", d.Deleted},
+	}
+
+	for _, s := range sections {
+		if len(s.items) == 0 {
+			continue
+		}
+		items := append([]string(nil), s.items...)
+		sort.Strings(items)
+		fmt.Fprintf(w, "%s:\n", s.label)
+		for _, item := range items {
+			fmt.Fprintf(w, "  %s\n", item)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/dukerupert/annuminas/pkg/dockerhub"
+)
+
+// redirectTransport rewrites every outgoing request to target's scheme and
+// host, so a Client built against the real baseURL constant can be pointed
+// at an httptest.Server via a fake http.RoundTripper.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newTestClient(t *testing.T, mux *http.ServeMux) *dockerhub.Client {
+	t.Helper()
+
+	mux.HandleFunc("/v2/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "test-jwt"})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("parse test server URL: %v", err)
+	}
+
+	return dockerhub.NewClient("user", "pass", dockerhub.WithHTTPClient(&http.Client{Transport: &redirectTransport{target: target}}))
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestApplyRepositories_DescriptionDrift(t *testing.T) {
+	tests := []struct {
+		name            string
+		spec            RepositorySpec
+		current         dockerhub.Repository
+		wantKind        string // "Updated" or "Unchanged"
+		wantPatched     string
+		wantPatchedFull string
+	}{
+		{
+			name:     "omitted description leaves existing description untouched",
+			spec:     RepositorySpec{Name: "repo"},
+			current:  dockerhub.Repository{Name: "repo", Description: "existing"},
+			wantKind: "Unchanged",
+		},
+		{
+			name:     "description matching current is unchanged",
+			spec:     RepositorySpec{Name: "repo", Description: strPtr("existing")},
+			current:  dockerhub.Repository{Name: "repo", Description: "existing"},
+			wantKind: "Unchanged",
+		},
+		{
+			name:        "description differing from current patches it",
+			spec:        RepositorySpec{Name: "repo", Description: strPtr("new")},
+			current:     dockerhub.Repository{Name: "repo", Description: "existing"},
+			wantKind:    "Updated",
+			wantPatched: "new",
+		},
+		{
+			name:     "full_description matching current is unchanged",
+			spec:     RepositorySpec{Name: "repo", FullDescription: "# Readme"},
+			current:  dockerhub.Repository{Name: "repo", FullDescription: "# Readme"},
+			wantKind: "Unchanged",
+		},
+		{
+			name:            "full_description differing from current patches it",
+			spec:            RepositorySpec{Name: "repo", FullDescription: "# New Readme"},
+			current:         dockerhub.Repository{Name: "repo", FullDescription: "# Readme"},
+			wantKind:        "Updated",
+			wantPatchedFull: "# New Readme",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPatch dockerhub.RepoPatch
+			patched := false
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v2/namespaces/ns/repositories/repo", func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(tt.current)
+			})
+			mux.HandleFunc("/v2/repositories/ns/repo/", func(w http.ResponseWriter, r *http.Request) {
+				patched = true
+				if r.Method != http.MethodPatch {
+					t.Fatalf("unexpected method %s on repo patch endpoint", r.Method)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&gotPatch); err != nil {
+					t.Fatalf("decode patch body: %v", err)
+				}
+				_ = json.NewEncoder(w).Encode(tt.current)
+			})
+			mux.HandleFunc("/v2/repositories/ns/repo/webhook_pipeline/", func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(map[string]any{"count": 0, "next": nil, "previous": nil, "results": []dockerhub.Webhook{}})
+			})
+			client := newTestClient(t, mux)
+
+			diff := &Diff{}
+			if err := applyRepositories(context.Background(), client, "ns", []RepositorySpec{tt.spec}, Config{}, diff); err != nil {
+				t.Fatalf("applyRepositories: %v", err)
+			}
+
+			switch tt.wantKind {
+			case "Unchanged":
+				if len(diff.Unchanged) != 1 || len(diff.Updated) != 0 {
+					t.Fatalf("diff = %+v, want a single Unchanged entry", diff)
+				}
+				if patched {
+					t.Errorf("server received a PATCH, want none sent")
+				}
+			case "Updated":
+				if len(diff.Updated) != 1 || len(diff.Unchanged) != 0 {
+					t.Fatalf("diff = %+v, want a single Updated entry", diff)
+				}
+				if tt.wantPatched != "" && (gotPatch.Description == nil || *gotPatch.Description != tt.wantPatched) {
+					t.Errorf("patched description = %v, want %q", gotPatch.Description, tt.wantPatched)
+				}
+				if tt.wantPatchedFull != "" && (gotPatch.FullDescription == nil || *gotPatch.FullDescription != tt.wantPatchedFull) {
+					t.Errorf("patched full_description = %v, want %q", gotPatch.FullDescription, tt.wantPatchedFull)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyRepositories_Prune(t *testing.T) {
+	var deleted []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/namespaces/ns/repositories", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"count":    2,
+			"next":     nil,
+			"previous": nil,
+			"results": []dockerhub.Repository{
+				{Name: "keep"},
+				{Name: "stale"},
+			},
+		})
+	})
+	mux.HandleFunc("/v2/namespaces/ns/repositories/stale", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("unexpected method %s on stale repo endpoint", r.Method)
+		}
+		deleted = append(deleted, "stale")
+	})
+	client := newTestClient(t, mux)
+
+	// "keep" is in the manifest so it must survive; "stale" is on Docker
+	// Hub but absent from the manifest so, with Prune set, it must go.
+	diff := &Diff{}
+	spec := []RepositorySpec{{Name: "keep", Description: strPtr("")}}
+	mux.HandleFunc("/v2/namespaces/ns/repositories/keep", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(dockerhub.Repository{Name: "keep"})
+	})
+	mux.HandleFunc("/v2/repositories/ns/keep/webhook_pipeline/", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"count": 0, "next": nil, "previous": nil, "results": []dockerhub.Webhook{}})
+	})
+
+	if err := applyRepositories(context.Background(), client, "ns", spec, Config{Prune: true}, diff); err != nil {
+		t.Fatalf("applyRepositories: %v", err)
+	}
+
+	if len(diff.Deleted) != 1 || diff.Deleted[0] != "repository/stale" {
+		t.Errorf("diff.Deleted = %v, want [repository/stale]", diff.Deleted)
+	}
+	if len(deleted) != 1 {
+		t.Errorf("DeleteRepo called %d times, want 1", len(deleted))
+	}
+}
+
+func TestApplyWebhooks(t *testing.T) {
+	var created []string
+	var deletedIDs []int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/namespaces/ns/repositories/repo", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(dockerhub.Repository{Name: "repo"})
+	})
+	// Prune also lists the namespace's repositories; "repo" is the only one,
+	// and it is in the manifest, so no repository is deleted.
+	mux.HandleFunc("/v2/namespaces/ns/repositories", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"count":    1,
+			"next":     nil,
+			"previous": nil,
+			"results":  []dockerhub.Repository{{Name: "repo"}},
+		})
+	})
+	mux.HandleFunc("/v2/repositories/ns/repo/webhook_pipeline/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"count":    2,
+				"next":     nil,
+				"previous": nil,
+				"results": []dockerhub.Webhook{
+					{ID: 1, Name: "keep"},
+					{ID: 2, Name: "stale"},
+				},
+			})
+		case http.MethodPost:
+			var payload struct {
+				Name       string `json:"name"`
+				WebhookURL string `json:"webhook_url"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode create payload: %v", err)
+			}
+			created = append(created, payload.Name)
+			_ = json.NewEncoder(w).Encode(dockerhub.Webhook{ID: 3, Name: payload.Name, WebhookURL: payload.WebhookURL})
+		}
+	})
+	mux.HandleFunc("/v2/repositories/ns/repo/webhook_pipeline/2/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("unexpected method %s on webhook delete endpoint", r.Method)
+		}
+		deletedIDs = append(deletedIDs, 2)
+	})
+	client := newTestClient(t, mux)
+
+	// "keep" is in the manifest so it must survive unchanged; "new" is
+	// absent from Docker Hub so it must be created; "stale" is on Docker
+	// Hub but absent from the manifest so, with Prune set, it must go.
+	spec := []RepositorySpec{{
+		Name:        "repo",
+		Description: strPtr(""),
+		Webhooks: []WebhookSpec{
+			{Name: "keep", URL: "https://example.com/keep"},
+			{Name: "new", URL: "https://example.com/new"},
+		},
+	}}
+
+	diff := &Diff{}
+	if err := applyRepositories(context.Background(), client, "ns", spec, Config{Prune: true}, diff); err != nil {
+		t.Fatalf("applyRepositories: %v", err)
+	}
+
+	if !containsString(diff.Unchanged, "webhook/repo/keep") {
+		t.Errorf("diff.Unchanged = %v, want it to contain webhook/repo/keep", diff.Unchanged)
+	}
+	if !containsString(diff.Created, "webhook/repo/new") {
+		t.Errorf("diff.Created = %v, want it to contain webhook/repo/new", diff.Created)
+	}
+	if !containsString(diff.Deleted, "webhook/repo/stale") {
+		t.Errorf("diff.Deleted = %v, want it to contain webhook/repo/stale", diff.Deleted)
+	}
+	if len(created) != 1 || created[0] != "new" {
+		t.Errorf("CreateWebhook calls = %v, want [new]", created)
+	}
+	if len(deletedIDs) != 1 || deletedIDs[0] != 2 {
+		t.Errorf("DeleteWebhook calls = %v, want [2]", deletedIDs)
+	}
+}
+
+func containsString(items []string, want string) bool {
+	for _, item := range items {
+		if item == want {
+			return true
+		}
+	}
+	return false
+}
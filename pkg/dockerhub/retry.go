@@ -0,0 +1,103 @@
+package dockerhub
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ClientOption configures a Client at construction time.
+type ClientOption func(*Client)
+
+// WithHTTPClient overrides the *http.Client used for all requests, e.g. to
+// inject a fake http.RoundTripper in tests.
+func WithHTTPClient(h *http.Client) ClientOption {
+	return func(c *Client) { c.http = h }
+}
+
+// WithRetries sets how many times a transport error, 5xx, or 429 response is
+// retried before doRequest gives up. The default is 3.
+func WithRetries(n int) ClientOption {
+	return func(c *Client) { c.retries = n }
+}
+
+// WithLogger attaches a logger that receives one message per retry decision.
+// By default a Client logs nothing.
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *Client) { c.logger = l }
+}
+
+// logRetry logs a single retry decision, if a logger was configured.
+func (c *Client) logRetry(attempt int, reason string, err error) {
+	if c.logger == nil {
+		return
+	}
+	if err != nil {
+		c.logger.Warn("retrying docker hub request", "attempt", attempt, "reason", reason, "error", err)
+		return
+	}
+	c.logger.Warn("retrying docker hub request", "attempt", attempt, "reason", reason)
+}
+
+// waitBackoff blocks for an exponentially growing, jittered delay based on
+// attempt, or returns ctx.Err() if ctx is cancelled first.
+func (c *Client) waitBackoff(ctx context.Context, attempt int) error {
+	return c.waitFor(ctx, backoffDelay(attempt))
+}
+
+// waitFor blocks for d, or returns ctx.Err() if ctx is cancelled first.
+func (c *Client) waitFor(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+const (
+	baseRetryDelay = 200 * time.Millisecond
+	maxRetryDelay  = 10 * time.Second
+)
+
+// backoffDelay returns an exponential backoff delay for attempt, with full
+// jitter to avoid synchronized retries across concurrent callers.
+func backoffDelay(attempt int) time.Duration {
+	delay := baseRetryDelay * time.Duration(1<<attempt)
+	if delay > maxRetryDelay || delay <= 0 {
+		delay = maxRetryDelay
+	}
+	return time.Duration(rand.Int63n(int64(delay)))
+}
+
+// retryAfterDelay determines how long to wait before retrying a 429 response,
+// preferring Retry-After and falling back to X-RateLimit-Reset, then to the
+// standard exponential backoff if neither header is present or parseable.
+func retryAfterDelay(h http.Header, attempt int) time.Duration {
+	if v := h.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+		if when, err := http.ParseTime(v); err == nil {
+			if d := time.Until(when); d > 0 {
+				return d
+			}
+		}
+	}
+
+	if v := h.Get("X-RateLimit-Reset"); v != "" {
+		if unix, err := strconv.ParseInt(v, 10, 64); err == nil {
+			if d := time.Until(time.Unix(unix, 0)); d > 0 {
+				return d
+			}
+		}
+	}
+
+	return backoffDelay(attempt)
+}